@@ -0,0 +1,269 @@
+package changelog
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+)
+
+var unreleasedRe = regexp.MustCompile(`(?i)^##\s*\[?(unreleased)\]?\s*$`)
+var sectionRe = regexp.MustCompile(`(?i)^###\s(added|changed|deprecated|fixed|removed|security)\s*$`)
+var changeRe = regexp.MustCompile(`(?i)^##\s*\[?(v?[0-9.]+)\]?\s*-?\s*([0-9\-]+)?\s*$`)
+var refRe = regexp.MustCompile(`(?i)^\[([^\]]+)\]:\s*(.*)$`)
+var itemLineRe = regexp.MustCompile(`^(\s*)-\s?(.*)$`)
+
+var canonicalSectionNames = map[string]string{
+	"added":      "Added",
+	"changed":    "Changed",
+	"deprecated": "Deprecated",
+	"fixed":      "Fixed",
+	"removed":    "Removed",
+	"security":   "Security",
+}
+
+// Document is the root of the changelog AST: the free-form header text, the
+// ordered list of versions (Unreleased first, if present), and the
+// compare/plain link references at the bottom of the file.
+type Document struct {
+	Header   string
+	Versions []*Version
+	LinkRefs []Reference
+}
+
+// Version is one "## [Tag] - Date" section of the changelog.
+type Version struct {
+	Tag      string
+	Time     time.Time
+	Sections []*Section
+}
+
+// Section is one "### Category" block within a Version.
+type Section struct {
+	Name  string
+	Items []*Item
+}
+
+// Item is a single changelog bullet. Indent preserves the exact leading
+// whitespace it was parsed with, and Children holds any more deeply
+// indented sub-bullets nested under it (see the v0.2.0 sub-point case),
+// so that WriteTo reproduces the original text byte-for-byte.
+type Item struct {
+	Indent   string
+	Text     string
+	Children []*Item
+}
+
+// Visitor is implemented by callers that want to traverse a Document with
+// Walk without re-deriving the version/section/item nesting themselves.
+type Visitor interface {
+	VisitVersion(v *Version) error
+	VisitSection(s *Section) error
+	VisitItem(i *Item) error
+}
+
+// Walk visits every version, section and item of doc, in document order,
+// depth-first over nested items. It stops and returns the first error any
+// Visit* call returns.
+func Walk(doc *Document, visitor Visitor) error {
+	for _, version := range doc.Versions {
+		if err := visitor.VisitVersion(version); err != nil {
+			return err
+		}
+		for _, section := range version.Sections {
+			if err := visitor.VisitSection(section); err != nil {
+				return err
+			}
+			for _, item := range section.Items {
+				if err := walkItem(item, visitor); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func walkItem(item *Item, visitor Visitor) error {
+	if err := visitor.VisitItem(item); err != nil {
+		return err
+	}
+	for _, child := range item.Children {
+		if err := walkItem(child, visitor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParseAST parses r into a Document, streaming line-by-line without
+// buffering the body into a single opaque blob: each bullet becomes an
+// Item, each "### Category" becomes a Section, and each "## [Tag]" becomes
+// a Version, so the result can be inspected and edited programmatically
+// (reordering versions, filtering categories, rewriting URLs, ...).
+func ParseAST(r io.Reader) (*Document, error) {
+	var doc Document
+	header := bytes.NewBufferString("")
+
+	var version *Version
+	var section *Section
+	var itemStack []itemStackEntry
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+
+		u := unreleasedRe.FindStringSubmatch(line)
+		s := sectionRe.FindStringSubmatch(line)
+		c := changeRe.FindStringSubmatch(line)
+		rf := refRe.FindStringSubmatch(line)
+		cr, isChangeRef := ParseReference(line)
+
+		isHeaderUnreleased := len(u) > 0
+		isHeaderChanges := len(c) > 0
+		isHeaderSection := len(s) > 0
+		isRef := len(rf) > 0
+		isHeader := isHeaderUnreleased || isHeaderChanges
+		isHeaderOrSubheader := isHeaderUnreleased || isHeaderChanges || isHeaderSection
+
+		if version == nil && isHeader {
+			doc.Header = header.String()
+		}
+
+		if version != nil && isHeader {
+			doc.Versions = append(doc.Versions, version)
+		}
+		if version != nil && isRef {
+			doc.Versions = append(doc.Versions, version)
+			version = nil
+		}
+
+		if isChangeRef {
+			doc.LinkRefs = append(doc.LinkRefs, cr)
+		} else if isRef {
+			doc.LinkRefs = append(doc.LinkRefs, Reference{Tag: rf[1], Raw: rf[0]})
+		}
+
+		if isHeaderUnreleased {
+			version = &Version{Tag: u[1]}
+		}
+
+		if isHeaderChanges {
+			t, err := time.Parse("2006-01-02", c[2])
+			if err != nil {
+				return nil, err
+			}
+			version = &Version{Tag: c[1], Time: t}
+		}
+
+		if isHeaderSection {
+			section = &Section{Name: canonicalSectionNames[strings.ToLower(s[1])]}
+			if version != nil {
+				version.Sections = append(version.Sections, section)
+			}
+			itemStack = nil
+		}
+
+		if section != nil && !isHeaderOrSubheader && !isRef {
+			appendItemLine(section, &itemStack, line)
+		}
+
+		if doc.Header == "" && version == nil {
+			header.WriteString(line)
+			header.WriteString("\n")
+		}
+	}
+
+	if version != nil {
+		doc.Versions = append(doc.Versions, version)
+	}
+
+	return &doc, nil
+}
+
+type itemStackEntry struct {
+	indent string
+	item   *Item
+}
+
+func appendItemLine(section *Section, stack *[]itemStackEntry, line string) {
+	m := itemLineRe.FindStringSubmatch(line)
+	if m == nil {
+		return
+	}
+	indent, text := m[1], m[2]
+
+	for len(*stack) > 0 && len((*stack)[len(*stack)-1].indent) >= len(indent) {
+		*stack = (*stack)[:len(*stack)-1]
+	}
+
+	item := &Item{Indent: indent, Text: text}
+	if len(*stack) == 0 {
+		section.Items = append(section.Items, item)
+	} else {
+		parent := (*stack)[len(*stack)-1].item
+		parent.Children = append(parent.Children, item)
+	}
+	*stack = append(*stack, itemStackEntry{indent: indent, item: item})
+}
+
+// RenderAST renders doc back to changelog markdown, using only the AST
+// (Header, Versions, LinkRefs) and not the Contents.Rest blob. Versions,
+// sections and items are rendered from their fields; each link reference is
+// written as its Raw line, so changing a reference's other fields does not
+// change its rendered line.
+func RenderAST(doc *Document) (string, error) {
+	buf := bytes.NewBufferString("")
+	_, err := doc.WriteTo(buf)
+	return buf.String(), err
+}
+
+func (doc *Document) WriteTo(w io.Writer) (int64, error) {
+	buf := bytes.NewBufferString("")
+	buf.WriteString(doc.Header)
+	for _, version := range doc.Versions {
+		version.WriteTo(buf)
+	}
+	for _, ref := range doc.LinkRefs {
+		ref.WriteTo(buf)
+	}
+	return buf.WriteTo(w)
+}
+
+func (version *Version) WriteTo(w io.Writer) (int64, error) {
+	buf := bytes.NewBufferString("")
+	fmt.Fprintf(buf, "## [%s]", version.Tag)
+	if version.Time.Unix() > 0 {
+		fmt.Fprintf(buf, " - %s", version.Time.Format("2006-01-02"))
+	}
+	buf.WriteString("\n")
+	for _, section := range version.Sections {
+		section.WriteTo(buf)
+	}
+	return buf.WriteTo(w)
+}
+
+func (section *Section) WriteTo(w io.Writer) (int64, error) {
+	buf := bytes.NewBufferString("")
+	fmt.Fprintf(buf, "### %s\n", section.Name)
+	for _, item := range section.Items {
+		item.WriteTo(buf)
+	}
+	buf.WriteString("\n")
+	return buf.WriteTo(w)
+}
+
+func (item *Item) WriteTo(w io.Writer) (int64, error) {
+	buf := bytes.NewBufferString("")
+	fmt.Fprintf(buf, "%s- %s\n", item.Indent, item.Text)
+	for _, child := range item.Children {
+		child.WriteTo(buf)
+	}
+	return buf.WriteTo(w)
+}
@@ -0,0 +1,65 @@
+package changelog
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAST_roundTrip(t *testing.T) {
+	in := bytes.NewBufferString(githubStyleTestLog)
+	doc, err := ParseAST(in)
+	assert.Nil(t, err)
+
+	out, err := RenderAST(doc)
+	assert.Nil(t, err)
+	assert.Equal(t, githubStyleTestLog, out)
+}
+
+func TestParseAST_subItems(t *testing.T) {
+	in := bytes.NewBufferString(githubStyleTestLog)
+	doc, err := ParseAST(in)
+	assert.Nil(t, err)
+
+	var changedSection *Section
+	for _, version := range doc.Versions {
+		if version.Tag != "v0.2.0" {
+			continue
+		}
+		for _, section := range version.Sections {
+			if section.Name == "Changed" {
+				changedSection = section
+			}
+		}
+	}
+
+	assert.NotNil(t, changedSection)
+	assert.Len(t, changedSection.Items, 1)
+	assert.Equal(t, "a thingy with some subpoints:", changedSection.Items[0].Text)
+	assert.Len(t, changedSection.Items[0].Children, 3)
+	assert.Equal(t, "this one", changedSection.Items[0].Children[0].Text)
+	assert.Equal(t, "\t", changedSection.Items[0].Children[0].Indent)
+}
+
+type countingVisitor struct {
+	versions, sections, items int
+}
+
+func (v *countingVisitor) VisitVersion(*Version) error { v.versions++; return nil }
+func (v *countingVisitor) VisitSection(*Section) error { v.sections++; return nil }
+func (v *countingVisitor) VisitItem(*Item) error       { v.items++; return nil }
+
+func TestWalk(t *testing.T) {
+	in := bytes.NewBufferString(githubStyleTestLog)
+	doc, err := ParseAST(in)
+	assert.Nil(t, err)
+
+	v := &countingVisitor{}
+	assert.Nil(t, Walk(doc, v))
+
+	assert.Equal(t, 4, v.versions)
+	assert.Equal(t, 6, v.sections)
+	// Fixed(2) + Removed(2) + Added(2) + Changed(1 parent + 3 children) + Deprecated(2) + Security(2)
+	assert.Equal(t, 14, v.items)
+}
@@ -0,0 +1,195 @@
+// Package autofill populates the Unreleased section of a changelog by
+// walking git history and classifying commit messages using the
+// Conventional Commits convention.
+package autofill
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+
+	"github.com/helstern/kacl/src/main/golang/changelog"
+)
+
+// ClassifierFunc maps a commit message to a changelog category ("Added",
+// "Fixed", ...), the subject text to use for the bullet (with any
+// Conventional Commits prefix stripped), and an optional note to append
+// after the bullet. matched is false when the commit should be skipped.
+type ClassifierFunc func(message string) (category string, subject string, note string, matched bool)
+
+// AutoFillOptions controls how AutoFill walks history and classifies commits.
+type AutoFillOptions struct {
+	// SinceTag overrides contents.Last.Tag as the boundary to stop walking at.
+	SinceTag string
+	// Classifier overrides the default Conventional Commits classifier.
+	Classifier ClassifierFunc
+	// Dedupe skips commits whose bullet text already appears in the
+	// target category.
+	Dedupe bool
+}
+
+var breakingFooterRe = regexp.MustCompile(`(?m)^BREAKING CHANGE:\s*(.*)$`)
+var conventionalRe = regexp.MustCompile(`^(\w+)(\([^)]*\))?(!)?:\s*(.*)$`)
+
+var prefixCategory = map[string]string{
+	"feat":      "Added",
+	"fix":       "Fixed",
+	"refactor":  "Changed",
+	"perf":      "Changed",
+	"chore":     "Changed",
+	"revert":    "Removed",
+	"sec":       "Security",
+	"security":  "Security",
+	"deprecate": "Deprecated",
+}
+
+// DefaultClassifier classifies a commit message using Conventional Commits
+// prefixes: feat -> Added, fix -> Fixed, refactor/perf/chore -> Changed,
+// revert -> Removed, sec/security -> Security, deprecate -> Deprecated. A
+// "BREAKING CHANGE:" footer or a "!" marker after the type routes the
+// commit to Changed with a note, overriding the prefix-derived category.
+// The returned subject has the Conventional Commits prefix stripped.
+func DefaultClassifier(message string) (string, string, string, bool) {
+	subjectLine := message
+	if i := strings.IndexByte(message, '\n'); i >= 0 {
+		subjectLine = message[:i]
+	}
+	subjectLine = strings.TrimSpace(subjectLine)
+
+	m := conventionalRe.FindStringSubmatch(subjectLine)
+	if m == nil {
+		return "", "", "", false
+	}
+
+	prefix := strings.ToLower(m[1])
+	breaking := m[3] == "!"
+	text := strings.TrimSpace(m[4])
+
+	if bm := breakingFooterRe.FindStringSubmatch(message); bm != nil {
+		breaking = true
+		if text == "" {
+			text = strings.TrimSpace(bm[1])
+		}
+	}
+
+	if breaking {
+		return "Changed", text, "BREAKING CHANGE", true
+	}
+
+	category, ok := prefixCategory[prefix]
+	if !ok {
+		return "", "", "", false
+	}
+	return category, text, "", true
+}
+
+// AutoFill walks commits from HEAD back to the commit tagged with
+// contents.Last.Tag (or opts.SinceTag, if set) and appends a bullet line to
+// the appropriate Changes field on contents.Unreleased for each classified
+// commit.
+func AutoFill(repoPath string, contents *changelog.Contents, opts AutoFillOptions) error {
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return fmt.Errorf("autofill: open repo: %w", err)
+	}
+
+	sinceTag := opts.SinceTag
+	if sinceTag == "" && contents.Last != nil {
+		sinceTag = contents.Last.Tag
+	}
+
+	var since *plumbing.Hash
+	if sinceTag != "" {
+		hash, err := resolveTag(repo, sinceTag)
+		if err != nil {
+			return fmt.Errorf("autofill: resolve tag %q: %w", sinceTag, err)
+		}
+		since = hash
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("autofill: resolve HEAD: %w", err)
+	}
+
+	commits, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return fmt.Errorf("autofill: walk log: %w", err)
+	}
+
+	classify := opts.Classifier
+	if classify == nil {
+		classify = DefaultClassifier
+	}
+
+	return commits.ForEach(func(c *object.Commit) error {
+		if since != nil && c.Hash == *since {
+			return storer.ErrStop
+		}
+
+		category, subject, note, ok := classify(c.Message)
+		if !ok {
+			return nil
+		}
+
+		bullet := "- " + subject
+		if note != "" {
+			bullet += fmt.Sprintf(" (%s)", note)
+		}
+
+		appendBullet(contents.Unreleased, category, bullet, opts.Dedupe)
+		return nil
+	})
+}
+
+func resolveTag(repo *git.Repository, tag string) (*plumbing.Hash, error) {
+	if ref, err := repo.Tag(tag); err == nil {
+		if tagObj, err := repo.TagObject(ref.Hash()); err == nil {
+			commit, err := tagObj.Commit()
+			if err != nil {
+				return nil, err
+			}
+			h := commit.Hash
+			return &h, nil
+		}
+		h := ref.Hash()
+		return &h, nil
+	}
+
+	hash, err := repo.ResolveRevision(plumbing.Revision(tag))
+	if err != nil {
+		return nil, err
+	}
+	return hash, nil
+}
+
+func appendBullet(changes *changelog.Changes, category, bullet string, dedupe bool) {
+	field := changes.Field(category)
+	if field == nil {
+		return
+	}
+
+	if dedupe && containsLine(*field, bullet) {
+		return
+	}
+
+	if *field == "" {
+		*field = bullet
+	} else {
+		*field = *field + "\n" + bullet
+	}
+}
+
+func containsLine(block, line string) bool {
+	for _, l := range strings.Split(block, "\n") {
+		if strings.TrimSpace(l) == strings.TrimSpace(line) {
+			return true
+		}
+	}
+	return false
+}
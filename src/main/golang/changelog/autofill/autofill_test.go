@@ -0,0 +1,89 @@
+package autofill
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/helstern/kacl/src/main/golang/changelog"
+)
+
+func TestDefaultClassifier(t *testing.T) {
+	cases := []struct {
+		message  string
+		category string
+		matched  bool
+	}{
+		{"feat: add login form", "Added", true},
+		{"fix: correct off-by-one", "Fixed", true},
+		{"refactor: extract helper", "Changed", true},
+		{"perf: speed up parser", "Changed", true},
+		{"chore: bump deps", "Changed", true},
+		{"revert: revert flaky change", "Removed", true},
+		{"sec: patch XSS", "Security", true},
+		{"deprecate: old flag", "Deprecated", true},
+		{"feat!: drop legacy API", "Changed", true},
+		{"docs: update readme", "", false},
+		{"no conventional prefix here", "", false},
+	}
+
+	for _, c := range cases {
+		category, _, _, matched := DefaultClassifier(c.message)
+		assert.Equal(t, c.matched, matched, c.message)
+		assert.Equal(t, c.category, category, c.message)
+	}
+}
+
+func TestDefaultClassifier_breakingFooter(t *testing.T) {
+	category, _, note, matched := DefaultClassifier("feat: add flag\n\nBREAKING CHANGE: removes old flag")
+	assert.True(t, matched)
+	assert.Equal(t, "Changed", category)
+	assert.Equal(t, "BREAKING CHANGE", note)
+}
+
+func TestDefaultClassifier_stripsPrefix(t *testing.T) {
+	_, subject, _, matched := DefaultClassifier("feat: add login form")
+	assert.True(t, matched)
+	assert.Equal(t, "add login form", subject)
+}
+
+func TestAutoFill(t *testing.T) {
+	dir, err := os.MkdirTemp("", "autofill")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	repo, err := git.PlainInit(dir, false)
+	assert.Nil(t, err)
+	wt, err := repo.Worktree()
+	assert.Nil(t, err)
+
+	sig := &object.Signature{Name: "tester", Email: "tester@example.com"}
+	commit := func(message string) {
+		_, err := wt.Commit(message, &git.CommitOptions{AllowEmptyCommits: true, Author: sig})
+		assert.Nil(t, err)
+	}
+
+	commit("chore: initial commit")
+	head, err := repo.Head()
+	assert.Nil(t, err)
+	_, err = repo.CreateTag("v1.0.0", head.Hash(), nil)
+	assert.Nil(t, err)
+
+	commit("feat: add export button")
+	commit("fix: correct export filename")
+	commit("docs: mention export in readme")
+
+	contents := &changelog.Contents{
+		Unreleased: changelog.NewChanges("Unreleased"),
+		Last:       &changelog.Changes{Tag: "v1.0.0"},
+	}
+
+	err = AutoFill(dir, contents, AutoFillOptions{Dedupe: true})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "- add export button", contents.Unreleased.Added)
+	assert.Equal(t, "- correct export filename", contents.Unreleased.Fixed)
+}
@@ -0,0 +1,166 @@
+// Package bridge imports changelog bullets from external issue trackers
+// (GitHub Issues, GitLab Issues), similar in spirit to git-bug's bridge
+// architecture.
+package bridge
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// BridgeEntry is a single tracker issue translated into changelog shape.
+type BridgeEntry struct {
+	Title    string
+	Number   int
+	URL      string
+	Category string
+}
+
+// Bridge imports issues updated since a point in time as BridgeEntry
+// values.
+type Bridge interface {
+	Import(since time.Time) ([]BridgeEntry, error)
+}
+
+// BridgeConfig carries the credentials and host needed to talk to a tracker.
+// Client defaults to http.DefaultClient when nil; tests should inject a
+// client that never performs real network calls.
+type BridgeConfig struct {
+	BaseURL string
+	Owner   string
+	Repo    string
+	Token   string
+	Client  *http.Client
+}
+
+func (config BridgeConfig) client() *http.Client {
+	if config.Client != nil {
+		return config.Client
+	}
+	return http.DefaultClient
+}
+
+// labelCategory maps tracker labels to a changelog category.
+var labelCategory = map[string]string{
+	"type:bug":     "Fixed",
+	"type:feature": "Added",
+	"security":     "Security",
+}
+
+func categoryFromLabels(labels []string) string {
+	for _, label := range labels {
+		if category, ok := labelCategory[strings.ToLower(label)]; ok {
+			return category
+		}
+	}
+	return ""
+}
+
+// GitHubBridge imports entries from the GitHub Issues REST API.
+type GitHubBridge struct {
+	config BridgeConfig
+}
+
+func NewGitHubBridge(config BridgeConfig) *GitHubBridge {
+	return &GitHubBridge{config: config}
+}
+
+type githubIssue struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	URL    string `json:"html_url"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+}
+
+func (b *GitHubBridge) Import(since time.Time) ([]BridgeEntry, error) {
+	url := fmt.Sprintf("%s/repos/%s/%s/issues?since=%s", b.config.BaseURL, b.config.Owner, b.config.Repo, since.Format(time.RFC3339))
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bridge: build github request: %w", err)
+	}
+	if b.config.Token != "" {
+		req.Header.Set("Authorization", "token "+b.config.Token)
+	}
+
+	resp, err := b.config.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("bridge: fetch github issues: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var issues []githubIssue
+	if err := json.NewDecoder(resp.Body).Decode(&issues); err != nil {
+		return nil, fmt.Errorf("bridge: decode github issues: %w", err)
+	}
+
+	entries := make([]BridgeEntry, 0, len(issues))
+	for _, issue := range issues {
+		labels := make([]string, len(issue.Labels))
+		for i, label := range issue.Labels {
+			labels[i] = label.Name
+		}
+		entries = append(entries, BridgeEntry{
+			Title:    issue.Title,
+			Number:   issue.Number,
+			URL:      issue.URL,
+			Category: categoryFromLabels(labels),
+		})
+	}
+	return entries, nil
+}
+
+// GitLabBridge imports entries from the GitLab Issues REST API.
+type GitLabBridge struct {
+	config BridgeConfig
+}
+
+func NewGitLabBridge(config BridgeConfig) *GitLabBridge {
+	return &GitLabBridge{config: config}
+}
+
+type gitlabIssue struct {
+	IID    int      `json:"iid"`
+	Title  string   `json:"title"`
+	WebURL string   `json:"web_url"`
+	Labels []string `json:"labels"`
+}
+
+func (b *GitLabBridge) Import(since time.Time) ([]BridgeEntry, error) {
+	url := fmt.Sprintf("%s/projects/%s%%2F%s/issues?updated_after=%s", b.config.BaseURL, b.config.Owner, b.config.Repo, since.Format(time.RFC3339))
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("bridge: build gitlab request: %w", err)
+	}
+	if b.config.Token != "" {
+		req.Header.Set("PRIVATE-TOKEN", b.config.Token)
+	}
+
+	resp, err := b.config.client().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("bridge: fetch gitlab issues: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var issues []gitlabIssue
+	if err := json.NewDecoder(resp.Body).Decode(&issues); err != nil {
+		return nil, fmt.Errorf("bridge: decode gitlab issues: %w", err)
+	}
+
+	entries := make([]BridgeEntry, 0, len(issues))
+	for _, issue := range issues {
+		entries = append(entries, BridgeEntry{
+			Title:    issue.Title,
+			Number:   issue.IID,
+			URL:      issue.WebURL,
+			Category: categoryFromLabels(issue.Labels),
+		})
+	}
+	return entries, nil
+}
@@ -0,0 +1,57 @@
+package bridge
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func jsonResponse(body string) *http.Client {
+	return &http.Client{
+		Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+			}, nil
+		}),
+	}
+}
+
+func TestGitHubBridge_Import(t *testing.T) {
+	client := jsonResponse(`[
+		{"number": 42, "title": "crash on save", "html_url": "https://github.com/o/r/issues/42", "labels": [{"name": "type:bug"}]},
+		{"number": 43, "title": "dark mode", "html_url": "https://github.com/o/r/issues/43", "labels": [{"name": "type:feature"}]},
+		{"number": 44, "title": "unlabeled", "html_url": "https://github.com/o/r/issues/44", "labels": []}
+	]`)
+
+	b := NewGitHubBridge(BridgeConfig{BaseURL: "https://api.github.com", Owner: "o", Repo: "r", Client: client})
+	entries, err := b.Import(time.Unix(0, 0))
+	assert.Nil(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, "Fixed", entries[0].Category)
+	assert.Equal(t, "Added", entries[1].Category)
+	assert.Equal(t, "", entries[2].Category)
+}
+
+func TestGitLabBridge_Import(t *testing.T) {
+	client := jsonResponse(`[
+		{"iid": 7, "title": "leaked token", "web_url": "https://gitlab.com/o/r/-/issues/7", "labels": ["security"]}
+	]`)
+
+	b := NewGitLabBridge(BridgeConfig{BaseURL: "https://gitlab.com/api/v4", Owner: "o", Repo: "r", Client: client})
+	entries, err := b.Import(time.Unix(0, 0))
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "Security", entries[0].Category)
+	assert.Equal(t, 7, entries[0].Number)
+}
@@ -0,0 +1,49 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/helstern/kacl/src/main/golang/changelog/bridge"
+)
+
+var issueNumberRe = regexp.MustCompile(`\(#(\d+)\)`)
+
+// MergeBridgeEntries appends each entry as a "- Title (#Number)" bullet to
+// the target Changes field matching entry.Category, skipping entries whose
+// issue number already appears as a bullet in that field. Entries with no
+// category hint are skipped.
+func MergeBridgeEntries(entries []bridge.BridgeEntry, target *Changes) error {
+	for _, entry := range entries {
+		if entry.Category == "" {
+			continue
+		}
+
+		field := target.Field(entry.Category)
+		if field == nil {
+			return fmt.Errorf("changelog: unknown bridge category %q", entry.Category)
+		}
+
+		if hasIssueNumber(*field, entry.Number) {
+			continue
+		}
+
+		bullet := fmt.Sprintf("- %s (#%d)", entry.Title, entry.Number)
+		if *field == "" {
+			*field = bullet
+		} else {
+			*field = *field + "\n" + bullet
+		}
+	}
+	return nil
+}
+
+func hasIssueNumber(block string, number int) bool {
+	want := fmt.Sprintf("#%d", number)
+	for _, m := range issueNumberRe.FindAllStringSubmatch(block, -1) {
+		if "#"+m[1] == want {
+			return true
+		}
+	}
+	return false
+}
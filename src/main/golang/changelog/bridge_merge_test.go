@@ -0,0 +1,34 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/helstern/kacl/src/main/golang/changelog/bridge"
+)
+
+func TestMergeBridgeEntries(t *testing.T) {
+	contents := &Contents{
+		Unreleased: &Changes{
+			Tag:   "Unreleased",
+			Fixed: "- existing bug (#1)",
+		},
+	}
+
+	entries := []bridge.BridgeEntry{
+		{Title: "existing bug", Number: 1, Category: "Fixed"},
+		{Title: "new bug", Number: 2, Category: "Fixed"},
+		{Title: "no category", Number: 3, Category: ""},
+	}
+
+	err := MergeBridgeEntries(entries, contents.Unreleased)
+	assert.Nil(t, err)
+	assert.Equal(t, "- existing bug (#1)\n- new bug (#2)", contents.Unreleased.Fixed)
+}
+
+func TestMergeBridgeEntries_unknownCategory(t *testing.T) {
+	contents := &Contents{Unreleased: NewChanges("Unreleased")}
+	err := MergeBridgeEntries([]bridge.BridgeEntry{{Title: "x", Number: 1, Category: "Bogus"}}, contents.Unreleased)
+	assert.NotNil(t, err)
+}
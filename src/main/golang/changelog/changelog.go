@@ -1,11 +1,9 @@
 package changelog
 
 import (
-	"bufio"
 	"bytes"
 	"fmt"
 	"io"
-	"regexp"
 	"strings"
 	"time"
 )
@@ -27,6 +25,27 @@ func NewChanges(tag string) *Changes {
 	}
 }
 
+// Field returns a pointer to the string field backing the given category
+// (case-insensitive: "added", "changed", "deprecated", "fixed", "removed",
+// "security"), or nil if category is not one of those.
+func (changes *Changes) Field(category string) *string {
+	switch strings.ToLower(category) {
+	case "added":
+		return &changes.Added
+	case "changed":
+		return &changes.Changed
+	case "deprecated":
+		return &changes.Deprecated
+	case "fixed":
+		return &changes.Fixed
+	case "removed":
+		return &changes.Removed
+	case "security":
+		return &changes.Security
+	}
+	return nil
+}
+
 func (changes *Changes) WriteTo(w io.Writer) (int64, error) {
 
 	buf := bytes.NewBufferString("")
@@ -66,140 +85,75 @@ type Contents struct {
 	Last       *Changes
 	Rest       string
 	Refs       []Reference
-}
 
-var unreleasedRe = regexp.MustCompile(`(?i)^##\s*\[?(unreleased)\]?\s*$`)
-var sectionRe = regexp.MustCompile(`(?i)^###\s(added|changed|deprecated|fixed|removed|security)\s*$`)
-var changeRe = regexp.MustCompile(`(?i)^##\s*\[?(v?[0-9.]+)\]?\s*-?\s*([0-9\-]+)?\s*$`)
-var changeRefRe = regexp.MustCompile(`(?i)^\[([^\]]+)\]:\s*(.*)/compare/(.*)(\.\.\.|%0D)(.*)$`)
-var refRe = regexp.MustCompile(`(?i)^\[([^\]]+)\]:\s*(.*)$`)
+	// InitialRevision is used as the From side of the oldest version's
+	// compare reference when Release is called on a changelog that has no
+	// prior released version to compare against.
+	InitialRevision string
+}
 
+// Parse reads a changelog and returns its Contents. It is a thin adapter
+// over ParseAST: the AST does the actual line-by-line parsing, and Parse
+// just flattens it into the legacy Contents shape for callers that don't
+// need the full tree.
 func Parse(r io.Reader) (*Contents, error) {
-	var contents Contents
-	var section string
-
-	header := bytes.NewBufferString("")
-	rest := bytes.NewBufferString("")
-	items := bytes.NewBufferString("")
-
-	var changes *Changes
-
-	finishSection := func() {
-		if section != "" && items.Len() > 0 {
-			switch strings.ToLower(section) {
-			case "added":
-				changes.Added = strings.Trim(items.String(), "\n ")
-			case "changed":
-				changes.Changed = strings.Trim(items.String(), "\n ")
-			case "deprecated":
-				changes.Deprecated = strings.Trim(items.String(), "\n ")
-			case "fixed":
-				changes.Fixed = strings.Trim(items.String(), "\n ")
-			case "removed":
-				changes.Removed = strings.Trim(items.String(), "\n ")
-			case "security":
-				changes.Security = strings.Trim(items.String(), "\n ")
-			}
-			items.Reset()
-		}
+	doc, err := ParseAST(r)
+	if err != nil {
+		return nil, err
 	}
+	return contentsFromDocument(doc), nil
+}
 
-	scanner := bufio.NewScanner(r)
-	for scanner.Scan() {
-		line := scanner.Text()
-		if err := scanner.Err(); err != nil {
-			return nil, err
-		}
-
-		u := unreleasedRe.FindStringSubmatch(line)
-		s := sectionRe.FindStringSubmatch(line)
-		c := changeRe.FindStringSubmatch(line)
-		rf := refRe.FindStringSubmatch(line)
-		cr := changeRefRe.FindStringSubmatch(line)
-
-		isHeaderUnreleased := len(u) > 0
-		isHeaderChanges := len(c) > 0
-		isHeaderSection := len(s) > 0
-		isRef := (len(rf) > 0)
-		isChangeRef := (len(cr) > 0)
-		isHeader := isHeaderUnreleased || isHeaderChanges
-		isHeaderOrSubheader := isHeaderUnreleased || isHeaderChanges || isHeaderSection
-		isEndOfSection := isHeaderOrSubheader || isRef
-
-		if changes == nil && isHeader {
-			contents.Header = header.String()
-		}
-
-		if changes != nil && isEndOfSection {
-			finishSection()
-		}
-		if changes != nil && isHeader {
-			contents.Changes = append(contents.Changes, changes)
-		}
-
-		if changes != nil && isRef {
-			contents.Changes = append(contents.Changes, changes)
-			changes = nil
-		}
-
-		if isRef && !isChangeRef {
-			ref := Reference{
-				Tag: rf[1],
-				Raw: rf[0],
-			}
-			contents.Refs = append(contents.Refs, ref)
-		}
+func contentsFromDocument(doc *Document) *Contents {
+	contents := &Contents{
+		Header: doc.Header,
+		Refs:   doc.LinkRefs,
+	}
 
-		if isChangeRef {
-			ref := NewReferenceFromRegexp(cr)
-			contents.Refs = append(contents.Refs, ref)
-		}
+	rest := bytes.NewBufferString("")
+	for _, version := range doc.Versions {
+		changes := changesFromVersion(version)
+		contents.Changes = append(contents.Changes, changes)
 
-		if isHeaderUnreleased {
-			changes = NewChanges(u[1])
+		if strings.EqualFold(version.Tag, "unreleased") {
 			contents.Unreleased = changes
-		}
-
-		if isHeaderChanges {
-			changes = NewChanges(c[1])
-			t, err := time.Parse("2006-01-02", c[2])
-			if err != nil {
-				return nil, err
+		} else {
+			version.WriteTo(rest)
+			if contents.Last == nil {
+				contents.Last = changes
 			}
-			changes.Time = t
 		}
+	}
+	contents.Rest = rest.String()
 
-		if len(s) > 0 {
-			section = s[1]
-		}
+	if contents.Unreleased == nil {
+		contents.Unreleased = NewChanges("Unreleased")
+	}
 
-		if section != "" && !isHeaderOrSubheader {
-			items.WriteString(line)
-			items.WriteString("\n")
-		}
+	return contents
+}
 
-		if changes != nil && strings.ToLower(changes.Tag) == "unreleased" {
-			continue
-		}
+func changesFromVersion(version *Version) *Changes {
+	changes := NewChanges(version.Tag)
+	changes.Time = version.Time
 
-		if contents.Header == "" && changes == nil {
-			header.WriteString(line)
-			header.WriteString("\n")
-		} else if !isRef {
-			rest.WriteString(line)
-			rest.WriteString("\n")
+	for _, section := range version.Sections {
+		field := changes.Field(section.Name)
+		if field == nil {
+			continue
 		}
-	}
-	contents.Rest = rest.String()
-	if changes != nil {
-		finishSection()
+		*field = renderItems(section.Items)
 	}
 
-	if contents.Unreleased == nil {
-		contents.Unreleased = NewChanges("Unreleased")
-	}
+	return changes
+}
 
-	return &contents, nil
+func renderItems(items []*Item) string {
+	buf := bytes.NewBufferString("")
+	for _, item := range items {
+		item.WriteTo(buf)
+	}
+	return strings.TrimRight(buf.String(), "\n")
 }
 
 func (contents *Contents) WriteTo(w io.Writer) (int64, error) {
@@ -0,0 +1,135 @@
+package changelog
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+const (
+	GITHUB_REFERENCE       = "github"
+	BITBUCKET_REFERENCE    = "bitbucket"
+	GITLAB_REFERENCE       = "gitlab"
+	GITEA_REFERENCE        = "gitea"
+	AZURE_DEVOPS_REFERENCE = "azure-devops"
+)
+
+// compareRefRe matches the GitHub/Bitbucket/Gitea compare link shape:
+// "BaseURL/compare/From...To" (GitHub, Gitea) or the Bitbucket "%0D"
+// separator variant.
+var compareRefRe = regexp.MustCompile(`(?i)^\[([^\]]+)\]:\s*(.*)/compare/(.*)(\.\.\.|%0D)(.*)$`)
+
+// gitlabRefRe matches GitLab's "BaseURL/-/compare/From...To" shape.
+var gitlabRefRe = regexp.MustCompile(`(?i)^\[([^\]]+)\]:\s*(.*)/-/compare/(.*)\.\.\.(.*)$`)
+
+// azureRefRe matches Azure DevOps' branch-compare query-string shape:
+// "BaseURL/_git/Repo/branchCompare?baseVersion=GTFrom&targetVersion=GTTo".
+var azureRefRe = regexp.MustCompile(`(?i)^\[([^\]]+)\]:\s*(.*)/_git/([^/]+)/branchCompare\?baseVersion=GT(.*)&targetVersion=GT(.*)$`)
+
+type Reference struct {
+	Type      string
+	Tag       string
+	Raw       string
+	From      string
+	To        string
+	Separator string
+	BaseURL   string
+	// Repo is only populated for AZURE_DEVOPS_REFERENCE, which embeds the
+	// repository name in its compare URL rather than in BaseURL.
+	Repo string
+}
+
+// ParseReference recognizes a compare-link changelog reference line in any
+// of the supported provider styles (GitHub, Bitbucket, GitLab, Gitea, Azure
+// DevOps) and returns the parsed Reference. The second return value is false
+// if line is not a compare-link reference.
+func ParseReference(line string) (Reference, bool) {
+	if m := azureRefRe.FindStringSubmatch(line); m != nil {
+		return Reference{
+			Type:    AZURE_DEVOPS_REFERENCE,
+			Tag:     m[1],
+			Raw:     m[0],
+			BaseURL: m[2],
+			Repo:    m[3],
+			From:    m[4],
+			To:      m[5],
+		}, true
+	}
+
+	if m := gitlabRefRe.FindStringSubmatch(line); m != nil {
+		return Reference{
+			Type:      GITLAB_REFERENCE,
+			Tag:       m[1],
+			Raw:       m[0],
+			BaseURL:   m[2],
+			From:      m[3],
+			Separator: "...",
+			To:        m[4],
+		}, true
+	}
+
+	if m := compareRefRe.FindStringSubmatch(line); m != nil {
+		return Reference{
+			Type:      hostTypeFor(m[2], m[4]),
+			Tag:       m[1],
+			Raw:       m[0],
+			BaseURL:   m[2],
+			From:      m[3],
+			Separator: m[4],
+			To:        m[5],
+		}, true
+	}
+
+	return Reference{}, false
+}
+
+// hostTypeFor disambiguates the shared GitHub/Gitea/Bitbucket compare shape:
+// the "%0D" separator is Bitbucket-specific, and a "gitea" substring in the
+// host is the only signal a self-hosted Gitea instance leaves in the URL.
+func hostTypeFor(baseURL, separator string) string {
+	if separator == "%0D" {
+		return BITBUCKET_REFERENCE
+	}
+	if strings.Contains(strings.ToLower(baseURL), "gitea") {
+		return GITEA_REFERENCE
+	}
+	return GITHUB_REFERENCE
+}
+
+func (ref Reference) WriteTo(w io.Writer) (int64, error) {
+	n, err := fmt.Fprintf(w, "%s\n", ref.Raw)
+	return int64(n), err
+}
+
+// NewCompareReference builds a compare-link Reference for the given tag,
+// inheriting BaseURL, Type, Separator and Repo from an existing reference
+// (such as the Unreleased reference), and renders its Raw line accordingly.
+func NewCompareReference(tag string, from string, to string, like Reference) Reference {
+	ref := Reference{
+		Type:      like.Type,
+		Tag:       tag,
+		From:      from,
+		To:        to,
+		Separator: like.Separator,
+		BaseURL:   like.BaseURL,
+		Repo:      like.Repo,
+	}
+	ref.Raw = ref.renderRaw()
+	return ref
+}
+
+func (ref Reference) renderRaw() string {
+	switch ref.Type {
+	case GITLAB_REFERENCE:
+		return fmt.Sprintf("[%s]: %s/-/compare/%s...%s", ref.Tag, ref.BaseURL, ref.From, ref.To)
+	case AZURE_DEVOPS_REFERENCE:
+		return fmt.Sprintf("[%s]: %s/_git/%s/branchCompare?baseVersion=GT%s&targetVersion=GT%s", ref.Tag, ref.BaseURL, ref.Repo, ref.From, ref.To)
+	default:
+		sep := ref.Separator
+		if sep == "" {
+			sep = "..."
+		}
+		return fmt.Sprintf("[%s]: %s/compare/%s%s%s", ref.Tag, ref.BaseURL, ref.From, sep, ref.To)
+	}
+}
@@ -0,0 +1,45 @@
+package changelog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReference_gitlab(t *testing.T) {
+	line := "[Unreleased]: https://gitlab.com/myuser/myproject/-/compare/v0.3.0...HEAD"
+	ref, ok := ParseReference(line)
+	assert.True(t, ok)
+	assert.Equal(t, GITLAB_REFERENCE, ref.Type)
+	assert.Equal(t, "https://gitlab.com/myuser/myproject", ref.BaseURL)
+	assert.Equal(t, "v0.3.0", ref.From)
+	assert.Equal(t, "HEAD", ref.To)
+	assert.Equal(t, line, ref.Raw)
+}
+
+func TestParseReference_gitea(t *testing.T) {
+	line := "[Unreleased]: https://gitea.example.com/myuser/myproject/compare/v0.3.0...HEAD"
+	ref, ok := ParseReference(line)
+	assert.True(t, ok)
+	assert.Equal(t, GITEA_REFERENCE, ref.Type)
+	assert.Equal(t, "v0.3.0", ref.From)
+	assert.Equal(t, "HEAD", ref.To)
+	assert.Equal(t, line, ref.Raw)
+}
+
+func TestParseReference_azureDevOps(t *testing.T) {
+	line := "[Unreleased]: https://dev.azure.com/myorg/myproject/_git/myrepo/branchCompare?baseVersion=GTv0.3.0&targetVersion=GTHEAD"
+	ref, ok := ParseReference(line)
+	assert.True(t, ok)
+	assert.Equal(t, AZURE_DEVOPS_REFERENCE, ref.Type)
+	assert.Equal(t, "https://dev.azure.com/myorg/myproject", ref.BaseURL)
+	assert.Equal(t, "myrepo", ref.Repo)
+	assert.Equal(t, "v0.3.0", ref.From)
+	assert.Equal(t, "HEAD", ref.To)
+	assert.Equal(t, line, ref.Raw)
+}
+
+func TestParseReference_notAChangeRef(t *testing.T) {
+	_, ok := ParseReference("[Keep a Changelog]: http://keepachangelog.com/en/1.0.0/")
+	assert.False(t, ok)
+}
@@ -0,0 +1,128 @@
+package changelog
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/go-git/go-git/v5"
+)
+
+// Release promotes the Unreleased section to a dated version: it copies
+// contents.Unreleased into a new Changes entry tagged version/date and
+// prepends it to contents.Changes (from which any Unreleased entry is
+// removed), resets contents.Unreleased to an empty
+// section, rebuilds contents.Rest from the updated Changes so WriteTo
+// renders the promoted version, and updates contents.Refs so the
+// Unreleased compare reference now starts at version and a new compare
+// reference for version is inserted, comparing from the previously latest
+// version (or contents.InitialRevision, if there was none).
+func (contents *Contents) Release(version string, date time.Time) error {
+	if contents.Unreleased == nil {
+		contents.Unreleased = NewChanges("Unreleased")
+	}
+
+	released := &Changes{
+		Tag:        version,
+		Added:      contents.Unreleased.Added,
+		Changed:    contents.Unreleased.Changed,
+		Deprecated: contents.Unreleased.Deprecated,
+		Fixed:      contents.Unreleased.Fixed,
+		Removed:    contents.Unreleased.Removed,
+		Security:   contents.Unreleased.Security,
+		Time:       date,
+	}
+
+	priorReleases := releasedOnly(contents.Changes)
+
+	previousTag := contents.InitialRevision
+	if len(priorReleases) > 0 {
+		previousTag = priorReleases[0].Tag
+	}
+
+	contents.Changes = append([]*Changes{released}, priorReleases...)
+	contents.Unreleased = NewChanges("Unreleased")
+
+	rest := bytes.NewBufferString("")
+	for _, c := range contents.Changes {
+		c.WriteTo(rest)
+	}
+	contents.Rest = rest.String()
+
+	idx := -1
+	for i := range contents.Refs {
+		if strings.EqualFold(contents.Refs[i].Tag, "unreleased") {
+			idx = i
+			break
+		}
+	}
+	if idx == -1 {
+		return fmt.Errorf("changelog: no Unreleased reference to update")
+	}
+
+	newRef := NewCompareReference(version, previousTag, version, contents.Refs[idx])
+
+	contents.Refs[idx].From = version
+	contents.Refs[idx].Raw = contents.Refs[idx].renderRaw()
+
+	refs := make([]Reference, 0, len(contents.Refs)+1)
+	refs = append(refs, contents.Refs[:idx+1]...)
+	refs = append(refs, newRef)
+	refs = append(refs, contents.Refs[idx+1:]...)
+	contents.Refs = refs
+
+	return nil
+}
+
+// Tag creates an annotated tag at HEAD in the repository at repoPath for the
+// most recently released version, using its rendered changelog section as
+// the tag message body. If signer is non-nil, the tag is GPG-signed.
+func (contents *Contents) Tag(repoPath string, message string, signer *openpgp.Entity) error {
+	priorReleases := releasedOnly(contents.Changes)
+	if len(priorReleases) == 0 {
+		return fmt.Errorf("changelog: no released version to tag")
+	}
+	latest := priorReleases[0]
+
+	repo, err := git.PlainOpen(repoPath)
+	if err != nil {
+		return fmt.Errorf("changelog: open repo: %w", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("changelog: resolve HEAD: %w", err)
+	}
+
+	body := bytes.NewBufferString("")
+	if _, err := latest.WriteTo(body); err != nil {
+		return err
+	}
+
+	opts := &git.CreateTagOptions{
+		Message: message + "\n\n" + body.String(),
+	}
+	if signer != nil {
+		opts.SignKey = signer
+	}
+
+	_, err = repo.CreateTag(latest.Tag, head.Hash(), opts)
+	if err != nil {
+		return fmt.Errorf("changelog: create tag %q: %w", latest.Tag, err)
+	}
+	return nil
+}
+
+// releasedOnly returns changes with any Unreleased entry filtered out.
+func releasedOnly(changes []*Changes) []*Changes {
+	released := make([]*Changes, 0, len(changes))
+	for _, c := range changes {
+		if strings.EqualFold(c.Tag, "unreleased") {
+			continue
+		}
+		released = append(released, c)
+	}
+	return released
+}
@@ -0,0 +1,100 @@
+package changelog
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelease(t *testing.T) {
+	buf := bytes.NewBufferString(githubStyleTestLog)
+	contents, err := Parse(buf)
+	assert.Nil(t, err)
+
+	err = contents.Release("v0.4.0", time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+
+	assert.Equal(t, "Unreleased", contents.Unreleased.Tag)
+	assert.Equal(t, "", contents.Unreleased.Fixed)
+
+	assert.Equal(t, "v0.4.0", contents.Changes[0].Tag)
+	assert.Equal(t, "- something broken\n- some issue", contents.Changes[0].Fixed)
+	assert.Equal(t, "- some old stuff\n- bad code", contents.Changes[0].Removed)
+	assert.Equal(t, "v0.3.0", contents.Changes[1].Tag)
+
+	var unreleasedRef, newRef *Reference
+	for i := range contents.Refs {
+		switch contents.Refs[i].Tag {
+		case "Unreleased":
+			unreleasedRef = &contents.Refs[i]
+		case "v0.4.0":
+			newRef = &contents.Refs[i]
+		}
+	}
+
+	assert.NotNil(t, unreleasedRef)
+	assert.Equal(t, "v0.4.0", unreleasedRef.From)
+	assert.Equal(t, "[Unreleased]: https://github.com/myuser/myproject/compare/v0.4.0...HEAD", unreleasedRef.Raw)
+
+	assert.NotNil(t, newRef)
+	assert.Equal(t, "v0.3.0", newRef.From)
+	assert.Equal(t, "v0.4.0", newRef.To)
+	assert.Equal(t, GITHUB_REFERENCE, newRef.Type)
+	assert.Equal(t, "[v0.4.0]: https://github.com/myuser/myproject/compare/v0.3.0...v0.4.0", newRef.Raw)
+}
+
+func TestRelease_writeTo(t *testing.T) {
+	buf := bytes.NewBufferString(githubStyleTestLog)
+	contents, err := Parse(buf)
+	assert.Nil(t, err)
+	header := contents.Header
+
+	err = contents.Release("v0.4.0", time.Date(2017, 1, 2, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+
+	out := bytes.NewBuffer(nil)
+	_, err = contents.WriteTo(out)
+	assert.Nil(t, err)
+
+	expected := header +
+		"## [Unreleased]\n" +
+		"## [v0.4.0] - 2017-01-02\n### Fixed\n- something broken\n- some issue\n\n### Removed\n- some old stuff\n- bad code\n\n" +
+		"## [v0.3.0] - 2016-12-03\n### Added\n- This awesome feature\n- More pewpew.\n\n" +
+		"## [v0.2.0] - 2015-10-06\n### Changed\n- a thingy with some subpoints:\n\t- this one\n\t- that one\n\t- yay!\n\n### Deprecated\n- legacy stuff\n- args of some function\n\n" +
+		"## [0.1.0] - 2014-09-02\n### Security\n- hard coded passwords have been removed\n- stack overflow issue solved!\n\n" +
+		"[Unreleased]: https://github.com/myuser/myproject/compare/v0.4.0...HEAD\n" +
+		"[v0.4.0]: https://github.com/myuser/myproject/compare/v0.3.0...v0.4.0\n" +
+		"[v0.3.0]: https://github.com/myuser/myproject/compare/v0.2.0...v0.3.0\n" +
+		"[v0.2.0]: https://github.com/myuser/myproject/compare/v0.1.0...v0.2.0\n" +
+		"[0.1.0]: https://github.com/myuser/myproject/compare/v0.0.8...v0.1.0\n"
+
+	assert.Equal(t, expected, out.String())
+}
+
+func TestRelease_noPriorVersion(t *testing.T) {
+	contents := &Contents{
+		Unreleased: NewChanges("Unreleased"),
+		Refs: []Reference{
+			NewCompareReference("Unreleased", "v0.1.0", "HEAD", Reference{
+				Type:      GITHUB_REFERENCE,
+				BaseURL:   "https://github.com/myuser/myproject",
+				Separator: "...",
+			}),
+		},
+		InitialRevision: "abc123",
+	}
+
+	err := contents.Release("v0.1.0", time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC))
+	assert.Nil(t, err)
+
+	var newRef *Reference
+	for i := range contents.Refs {
+		if contents.Refs[i].Tag == "v0.1.0" {
+			newRef = &contents.Refs[i]
+		}
+	}
+	assert.NotNil(t, newRef)
+	assert.Equal(t, "abc123", newRef.From)
+}